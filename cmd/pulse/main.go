@@ -0,0 +1,232 @@
+// Command pulse runs the Pulse monitoring daemon.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/rcourtman/pulse-go-rewrite/internal/api"
+	"github.com/rcourtman/pulse-go-rewrite/internal/cache"
+	"github.com/rcourtman/pulse-go-rewrite/internal/cluster"
+	"github.com/rcourtman/pulse-go-rewrite/internal/config"
+	"github.com/rcourtman/pulse-go-rewrite/internal/logging"
+	"github.com/rcourtman/pulse-go-rewrite/internal/search"
+	"github.com/rcourtman/pulse-go-rewrite/internal/secret"
+	"github.com/rcourtman/pulse-go-rewrite/internal/ws"
+)
+
+func main() {
+	defer secret.Purge()
+
+	configPath := os.Getenv("PULSE_CONFIG_FILE")
+	if configPath == "" {
+		configPath = "config.yaml"
+	}
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to load configuration")
+	}
+
+	logger, err := logging.Build(cfg.Logging)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to build logger")
+	}
+	log.Logger = logger.Logger
+
+	store := config.NewStore(cfg)
+	hub := ws.NewHub()
+
+	watcher, err := config.WatchFile(configPath, store, hub)
+	if err != nil {
+		log.Warn().Err(err).Msg("config hot-reload disabled: failed to start watcher")
+	} else {
+		defer watcher.Close()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	go watchSIGHUP(logger)
+
+	eventIndex, err := search.Open(cfg.DataDir)
+	if err != nil {
+		log.Warn().Err(err).Msg("event search disabled: failed to open index")
+	} else {
+		defer eventIndex.Close()
+		retention := time.Duration(cfg.Search.RetentionDays) * 24 * time.Hour
+		compactor := search.NewCompactor(eventIndex, retention, time.Hour)
+		go compactor.Run(ctx)
+	}
+
+	var clusterDeps *api.ClusterDeps
+	if cfg.Cluster.Enabled() {
+		mgr, err := setupCluster(ctx, cfg.Cluster)
+		if err != nil {
+			log.Warn().Err(err).Msg("cluster mode disabled: failed to join")
+		} else {
+			defer mgr.Close()
+			replServer := cluster.NewReplicationServer()
+			clusterDeps = &api.ClusterDeps{Manager: mgr, Replicator: replServer}
+
+			for _, node := range cfg.Nodes {
+				mgr.Campaign(ctx, node.Name)
+			}
+		}
+	}
+
+	pveCache, err := buildCache(cfg.Cache)
+	if err != nil {
+		log.Warn().Err(err).Msg("pve response cache disabled")
+	}
+
+	server := api.NewServer(store, hub, eventIndex, pveCache, clusterDeps)
+
+	if clusterDeps != nil {
+		for _, node := range cfg.Nodes {
+			runClusterReplication(ctx, clusterDeps.Manager, clusterDeps.Replicator, server, node, eventIndex)
+		}
+	}
+
+	log.Info().Str("addr", store.Get().ListenAddr).Msg("starting pulse")
+	if err := server.Start(ctx); err != nil {
+		log.Fatal().Err(err).Msg("server stopped unexpectedly")
+	}
+}
+
+// runClusterReplication keeps node's replication role up to date: while
+// this instance is the elected leader for node, it periodically publishes
+// a Snapshot for connected followers; otherwise, if rendezvous hashing
+// assigns this instance to shadow node, it dials the current leader's
+// replication endpoint and keeps the resulting ReplicaClient registered
+// with server so /api/cluster/status can serve read-only state from it.
+func runClusterReplication(ctx context.Context, mgr *cluster.Manager, replServer *cluster.ReplicationServer, server *api.Server, node config.ProxmoxNode, eventIndex *search.Index) {
+	go func() {
+		var version uint64
+		var shadowing bool
+
+		ticker := time.NewTicker(5 * time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			if mgr.IsLeader(node.Name) {
+				shadowing = false
+				version++
+				data := fmt.Sprintf("node=%s", node.Name)
+				if eventIndex != nil {
+					if n, err := eventIndex.DocCount(); err == nil {
+						data = fmt.Sprintf("node=%s indexed_events=%d", node.Name, n)
+					}
+				}
+				replServer.Publish(cluster.Snapshot{Version: version, Data: []byte(data), SentAt: time.Now()})
+				continue
+			}
+
+			if shadowing {
+				continue
+			}
+
+			owns, err := mgr.OwnsNode(ctx, node.Name)
+			if err != nil || !owns {
+				continue
+			}
+
+			leaderAddr, err := mgr.LeaderAddr(ctx, node.Name)
+			if err != nil || leaderAddr == "" {
+				continue
+			}
+
+			shadowing = true
+			replicaURL := "ws://" + leaderAddr + "/internal/cluster/replicate"
+			server.SetReplicaClient(node.Name, cluster.NewReplicaClient(ctx, replicaURL))
+			log.Info().Str("node", node.Name).Str("leader", leaderAddr).Msg("cluster: shadowing leader replication stream")
+		}
+	}()
+}
+
+// buildCache constructs the shared PVE/PBS response cache from cfg,
+// applying any per-endpoint TTL overrides on top of the default
+// fresh/stale windows.
+func buildCache(cfg config.CacheConfig) (*cache.Cache, error) {
+	defaultTTL := cache.TTLConfig{
+		Fresh: time.Duration(cfg.FreshSeconds) * time.Second,
+		Stale: time.Duration(cfg.StaleSeconds) * time.Second,
+	}
+
+	overrides := make(map[string]cache.TTLConfig, len(cfg.Overrides))
+	for _, o := range cfg.Overrides {
+		ttl := defaultTTL
+		if o.FreshSec != 0 {
+			ttl.Fresh = time.Duration(o.FreshSec) * time.Second
+		}
+		if o.StaleSec != 0 {
+			ttl.Stale = time.Duration(o.StaleSec) * time.Second
+		}
+		overrides[o.Endpoint] = ttl
+	}
+
+	return cache.New(cfg.MaxEntries, defaultTTL, overrides)
+}
+
+// setupCluster builds the Registry backend named by cfg.Backend and wraps
+// it in a cluster.Manager. A single node's own leadership drives whether
+// this instance polls and replicates state for that node, or defers to the
+// elected leader and only serves read-only traffic.
+func setupCluster(ctx context.Context, cfg config.ClusterConfig) (*cluster.Manager, error) {
+	var (
+		registry cluster.Registry
+		err      error
+	)
+
+	switch cluster.Backend(cfg.Backend) {
+	case cluster.BackendConsul:
+		endpoint := ""
+		if len(cfg.Endpoints) > 0 {
+			endpoint = cfg.Endpoints[0]
+		}
+		registry, err = cluster.NewConsulRegistry(endpoint, cfg.Prefix, cfg.AdvertiseAddr)
+	case cluster.BackendEtcd:
+		registry, err = cluster.NewEtcdRegistry(cfg.Endpoints, cfg.Prefix, cfg.AdvertiseAddr)
+	default:
+		return nil, fmt.Errorf("unknown cluster backend %q", cfg.Backend)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return cluster.NewManager(registry, cfg.AdvertiseAddr,
+		func(pveCluster string) {
+			log.Info().Str("cluster", pveCluster).Msg("this instance is now leader")
+		},
+		func(pveCluster string) {
+			log.Warn().Str("cluster", pveCluster).Msg("this instance is now a follower")
+		},
+	), nil
+}
+
+// watchSIGHUP reopens the rotating log file on SIGHUP so external
+// logrotate-style tooling can rename the old file out from under us without
+// losing subsequent writes.
+func watchSIGHUP(logger *logging.Logger) {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	for range sighup {
+		if err := logger.Reopen(); err != nil {
+			log.Warn().Err(err).Msg("failed to reopen log file on SIGHUP")
+			continue
+		}
+		log.Info().Msg("log file reopened after SIGHUP")
+	}
+}
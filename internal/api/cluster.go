@@ -0,0 +1,86 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/rcourtman/pulse-go-rewrite/internal/cluster"
+)
+
+// ClusterDeps bundles the clustering pieces the API layer needs: the
+// Manager to report role/ownership, and the replication server that
+// accepts follower connections when this instance is a leader. Both are
+// nil when clustering is disabled.
+type ClusterDeps struct {
+	Manager    *cluster.Manager
+	Replicator *cluster.ReplicationServer
+}
+
+// replicaClients tracks, per PVE node this instance shadows as a
+// follower, the live connection replicating that node's leader state.
+type replicaClients struct {
+	mu      sync.RWMutex
+	clients map[string]*cluster.ReplicaClient
+}
+
+func newReplicaClients() *replicaClients {
+	return &replicaClients{clients: make(map[string]*cluster.ReplicaClient)}
+}
+
+// Set registers (or replaces) the ReplicaClient shadowing node.
+func (r *replicaClients) Set(node string, client *cluster.ReplicaClient) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.clients[node] = client
+}
+
+func (r *replicaClients) get(node string) *cluster.ReplicaClient {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.clients[node]
+}
+
+// SetReplicaClient registers the ReplicaClient shadowing node's leader
+// state, so /api/cluster/status can report how fresh its replicated view
+// is. Called by main once a follower starts shadowing a node.
+func (s *Server) SetReplicaClient(node string, client *cluster.ReplicaClient) {
+	s.replicas.Set(node, client)
+}
+
+type nodeClusterStatus struct {
+	Node            string `json:"node"`
+	Leader          bool   `json:"leader"`
+	ShadowingLeader bool   `json:"shadowing_leader"`
+	ReplicaVersion  uint64 `json:"replica_version,omitempty"`
+}
+
+// handleClusterStatus reports, for every configured node, whether this
+// instance currently holds leadership for it, and — when it doesn't —
+// whether it is the rendezvous-assigned follower replicating that
+// leader's state for local read-only serving.
+func (s *Server) handleClusterStatus(w http.ResponseWriter, r *http.Request) {
+	store := s.store.Get()
+	statuses := make([]nodeClusterStatus, 0, len(store.Nodes))
+
+	for _, node := range store.Nodes {
+		st := nodeClusterStatus{Node: node.Name, Leader: s.clusterMgr.IsLeader(node.Name)}
+
+		if !st.Leader {
+			owns, err := s.clusterMgr.OwnsNode(r.Context(), node.Name)
+			if err == nil && owns {
+				st.ShadowingLeader = true
+				if rc := s.replicas.get(node.Name); rc != nil {
+					if snap := rc.Latest(); snap != nil {
+						st.ReplicaVersion = snap.Version
+					}
+				}
+			}
+		}
+
+		statuses = append(statuses, st)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(statuses)
+}
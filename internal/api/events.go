@@ -0,0 +1,79 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rcourtman/pulse-go-rewrite/internal/search"
+)
+
+const (
+	defaultSearchLimit = 50
+	maxSearchLimit     = 500
+)
+
+// handleEventsSearch serves GET /api/events/search?q=...&from=...&to=...&limit=...
+// running a bleve query-string search over the event/alert history index.
+func (s *Server) handleEventsSearch(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	params := search.SearchParams{
+		QueryString: q.Get("q"),
+		Limit:       defaultSearchLimit,
+	}
+
+	if v := q.Get("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			http.Error(w, "invalid from: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		params.From = t
+	}
+	if v := q.Get("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			http.Error(w, "invalid to: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		params.To = t
+	}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		if n > maxSearchLimit {
+			n = maxSearchLimit
+		}
+		params.Limit = n
+	}
+	if v := q.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			http.Error(w, "invalid offset", http.StatusBadRequest)
+			return
+		}
+		params.Offset = n
+	}
+
+	start := time.Now()
+	results, err := s.searchIndex.Search(params)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	elapsed := time.Since(start)
+
+	s.hub.Broadcast("events.search.stats", map[string]any{
+		"query_string": params.QueryString,
+		"total":        results.Total,
+		"took_ms":      elapsed.Milliseconds(),
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(results)
+}
@@ -0,0 +1,94 @@
+// Package api implements Pulse's HTTP and WebSocket surface.
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/rcourtman/pulse-go-rewrite/internal/cache"
+	"github.com/rcourtman/pulse-go-rewrite/internal/cluster"
+	"github.com/rcourtman/pulse-go-rewrite/internal/config"
+	"github.com/rcourtman/pulse-go-rewrite/internal/search"
+	"github.com/rcourtman/pulse-go-rewrite/internal/ws"
+)
+
+// Server wires the HTTP router and WebSocket hub on top of a config Store so
+// both reflect the latest reloaded configuration.
+type Server struct {
+	store       *config.Store
+	hub         *ws.Hub
+	searchIndex *search.Index
+	cache       *cache.Cache
+	clusterMgr  *cluster.Manager
+	replicator  *cluster.ReplicationServer
+	replicas    *replicaClients
+	http        *http.Server
+}
+
+// NewServer builds a Server. searchIndex, sharedCache, and clusterDeps may
+// be nil/zero, in which case the corresponding endpoints are omitted. The
+// returned instance still needs Start.
+func NewServer(store *config.Store, hub *ws.Hub, searchIndex *search.Index, sharedCache *cache.Cache, clusterDeps *ClusterDeps) *Server {
+	s := &Server{store: store, hub: hub, searchIndex: searchIndex, cache: sharedCache, replicas: newReplicaClients()}
+	if clusterDeps != nil {
+		s.clusterMgr = clusterDeps.Manager
+		s.replicator = clusterDeps.Replicator
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/health", s.handleHealth)
+	mux.HandleFunc("/api/metrics", s.handleMetrics)
+	mux.HandleFunc("/ws", hub.ServeHTTP)
+	if searchIndex != nil {
+		mux.HandleFunc("/api/events/search", s.handleEventsSearch)
+	}
+	if s.clusterMgr != nil {
+		mux.HandleFunc("/api/cluster/status", s.handleClusterStatus)
+	}
+	if s.replicator != nil {
+		mux.HandleFunc("/internal/cluster/replicate", s.replicator.ServeHTTP)
+	}
+
+	s.http = &http.Server{
+		Addr:              store.Get().ListenAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return s
+}
+
+// Start begins serving HTTP on the configured listen address. It blocks
+// until the server stops or ctx is cancelled.
+func (s *Server) Start(ctx context.Context) error {
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		s.http.Shutdown(shutdownCtx)
+	}()
+
+	if err := s.http.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(`{"status":"ok"}`))
+}
+
+// handleMetrics reports cache hit/miss counters alongside whatever other
+// operational metrics Pulse exposes.
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if s.cache == nil {
+		w.Write([]byte(`{}`))
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]any{
+		"pve_cache": s.cache.Stats(),
+	})
+}
@@ -0,0 +1,149 @@
+// Package cache provides a shared, bounded response cache sitting between
+// the Proxmox HTTP client and the collectors, so slow-changing resources
+// (cluster resources, storage config, backup job definitions) aren't
+// re-fetched from the PVE/PBS API on every poll cycle.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync/atomic"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/singleflight"
+)
+
+// FetchFunc retrieves a fresh value for a cache miss or expired entry.
+type FetchFunc func() ([]byte, error)
+
+type entry struct {
+	body      []byte
+	fetchedAt time.Time
+}
+
+// TTLConfig controls how long an endpoint's cached responses stay fresh,
+// and how much longer a stale entry is served while a refresh runs in the
+// background.
+type TTLConfig struct {
+	Fresh time.Duration
+	Stale time.Duration
+}
+
+// Cache is a bounded, TTL-aware, stale-while-revalidate cache keyed by
+// (endpoint, token hash, query). It is safe for concurrent use.
+type Cache struct {
+	lru        *lru.Cache[string, *entry]
+	group      singleflight.Group
+	defaultTTL TTLConfig
+	overrides  map[string]TTLConfig
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// New creates a Cache holding at most maxEntries responses.
+func New(maxEntries int, defaultTTL TTLConfig, overrides map[string]TTLConfig) (*Cache, error) {
+	l, err := lru.New[string, *entry](maxEntries)
+	if err != nil {
+		return nil, err
+	}
+	if overrides == nil {
+		overrides = make(map[string]TTLConfig)
+	}
+	return &Cache{lru: l, defaultTTL: defaultTTL, overrides: overrides}, nil
+}
+
+// KeyHashToken reduces a raw API token down to a short, non-reversible
+// identifier suitable for inclusion in a cache key, so the cache never
+// holds the token itself.
+func KeyHashToken(token []byte) string {
+	sum := sha256.Sum256(token)
+	return hex.EncodeToString(sum[:8])
+}
+
+func (c *Cache) key(endpoint, tokenHash, query string) string {
+	return endpoint + "\x00" + tokenHash + "\x00" + query
+}
+
+func (c *Cache) ttlFor(endpoint string) TTLConfig {
+	if ttl, ok := c.overrides[endpoint]; ok {
+		return ttl
+	}
+	return c.defaultTTL
+}
+
+// Get returns the cached response for (endpoint, tokenHash, query),
+// fetching it via fetch according to the endpoint's freshness window:
+//
+//   - fresh: returned immediately, no fetch.
+//   - stale: returned immediately, fetch runs asynchronously to refresh it.
+//   - expired or missing: the caller blocks on a singleflight-deduplicated
+//     fetch, so concurrent requests for the same key only hit the API once.
+func (c *Cache) Get(endpoint, tokenHash, query string, fetch FetchFunc) ([]byte, error) {
+	key := c.key(endpoint, tokenHash, query)
+	ttl := c.ttlFor(endpoint)
+
+	if e, ok := c.lru.Get(key); ok {
+		age := time.Since(e.fetchedAt)
+		if age < ttl.Fresh {
+			c.hits.Add(1)
+			return e.body, nil
+		}
+		if age < ttl.Fresh+ttl.Stale {
+			c.hits.Add(1)
+			c.refreshAsync(key, fetch)
+			return e.body, nil
+		}
+	}
+
+	c.misses.Add(1)
+	body, err, _ := c.group.Do(key, func() (any, error) {
+		body, err := fetch()
+		if err != nil {
+			return nil, err
+		}
+		c.lru.Add(key, &entry{body: body, fetchedAt: time.Now()})
+		return body, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return body.([]byte), nil
+}
+
+// refreshAsync kicks off a background refetch for key, deduplicated via
+// the same singleflight group used by blocking fetches.
+func (c *Cache) refreshAsync(key string, fetch FetchFunc) {
+	go func() {
+		_, err, _ := c.group.Do(key, func() (any, error) {
+			body, err := fetch()
+			if err != nil {
+				return nil, err
+			}
+			c.lru.Add(key, &entry{body: body, fetchedAt: time.Now()})
+			return body, nil
+		})
+		if err != nil {
+			log.Warn().Err(err).Str("key", key).Msg("cache: stale-while-revalidate refresh failed, keeping stale entry")
+		}
+	}()
+}
+
+// Stats is a snapshot of the cache's hit/miss counters, suitable for
+// exposing on the metrics endpoint.
+type Stats struct {
+	Hits    uint64 `json:"hits"`
+	Misses  uint64 `json:"misses"`
+	Entries int    `json:"entries"`
+}
+
+// Stats returns the current hit/miss/entry counts.
+func (c *Cache) Stats() Stats {
+	return Stats{
+		Hits:    c.hits.Load(),
+		Misses:  c.misses.Load(),
+		Entries: c.lru.Len(),
+	}
+}
@@ -0,0 +1,164 @@
+package cache
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestCache(t *testing.T, ttl TTLConfig) *Cache {
+	t.Helper()
+	c, err := New(16, ttl, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return c
+}
+
+func TestCacheGetMissFetchesAndStores(t *testing.T) {
+	c := newTestCache(t, TTLConfig{Fresh: time.Hour, Stale: time.Hour})
+
+	var calls atomic.Int32
+	fetch := func() ([]byte, error) {
+		calls.Add(1)
+		return []byte("v1"), nil
+	}
+
+	body, err := c.Get("ep", "tok", "", fetch)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if string(body) != "v1" {
+		t.Fatalf("body = %q, want v1", body)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("fetch called %d times, want 1", calls.Load())
+	}
+	if stats := c.Stats(); stats.Misses != 1 || stats.Hits != 0 {
+		t.Fatalf("stats = %+v, want 1 miss 0 hits", stats)
+	}
+}
+
+func TestCacheGetFreshEntrySkipsFetch(t *testing.T) {
+	c := newTestCache(t, TTLConfig{Fresh: time.Hour, Stale: time.Hour})
+
+	var calls atomic.Int32
+	fetch := func() ([]byte, error) {
+		calls.Add(1)
+		return []byte("v1"), nil
+	}
+
+	if _, err := c.Get("ep", "tok", "", fetch); err != nil {
+		t.Fatalf("first Get: %v", err)
+	}
+	body, err := c.Get("ep", "tok", "", fetch)
+	if err != nil {
+		t.Fatalf("second Get: %v", err)
+	}
+	if string(body) != "v1" {
+		t.Fatalf("body = %q, want v1", body)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("fetch called %d times for a fresh hit, want 1", calls.Load())
+	}
+	if stats := c.Stats(); stats.Hits != 1 || stats.Misses != 1 {
+		t.Fatalf("stats = %+v, want 1 hit 1 miss", stats)
+	}
+}
+
+func TestCacheGetStaleEntryServesStaleAndRefreshesAsync(t *testing.T) {
+	c := newTestCache(t, TTLConfig{Fresh: 10 * time.Millisecond, Stale: time.Hour})
+
+	var calls atomic.Int32
+	fetch := func() ([]byte, error) {
+		n := calls.Add(1)
+		if n == 1 {
+			return []byte("v1"), nil
+		}
+		return []byte("v2"), nil
+	}
+
+	if _, err := c.Get("ep", "tok", "", fetch); err != nil {
+		t.Fatalf("first Get: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	body, err := c.Get("ep", "tok", "", fetch)
+	if err != nil {
+		t.Fatalf("stale Get: %v", err)
+	}
+	if string(body) != "v1" {
+		t.Fatalf("stale Get returned %q, want the old value v1 served immediately", body)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for calls.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if calls.Load() < 2 {
+		t.Fatalf("expected a background refresh fetch, fetch called %d times", calls.Load())
+	}
+}
+
+func TestCacheGetExpiredEntryRefetchesSynchronously(t *testing.T) {
+	c := newTestCache(t, TTLConfig{Fresh: time.Millisecond, Stale: time.Millisecond})
+
+	var calls atomic.Int32
+	fetch := func() ([]byte, error) {
+		n := calls.Add(1)
+		if n == 1 {
+			return []byte("v1"), nil
+		}
+		return []byte("v2"), nil
+	}
+
+	if _, err := c.Get("ep", "tok", "", fetch); err != nil {
+		t.Fatalf("first Get: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	body, err := c.Get("ep", "tok", "", fetch)
+	if err != nil {
+		t.Fatalf("expired Get: %v", err)
+	}
+	if string(body) != "v2" {
+		t.Fatalf("expired Get returned %q, want the freshly fetched value v2", body)
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("fetch called %d times, want 2 (expired entries refetch synchronously)", calls.Load())
+	}
+}
+
+func TestCacheGetPropagatesFetchError(t *testing.T) {
+	c := newTestCache(t, TTLConfig{Fresh: time.Hour, Stale: time.Hour})
+
+	wantErr := errors.New("boom")
+	_, err := c.Get("ep", "tok", "", func() ([]byte, error) {
+		return nil, wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("err = %v, want %v", err, wantErr)
+	}
+	if stats := c.Stats(); stats.Entries != 0 {
+		t.Fatalf("expected nothing cached after a failed fetch, got %+v", stats)
+	}
+}
+
+func TestCacheGetKeysByEndpointTokenAndQuery(t *testing.T) {
+	c := newTestCache(t, TTLConfig{Fresh: time.Hour, Stale: time.Hour})
+
+	var calls atomic.Int32
+	fetch := func() ([]byte, error) {
+		calls.Add(1)
+		return []byte("v"), nil
+	}
+
+	c.Get("ep", "tokA", "", fetch)
+	c.Get("ep", "tokB", "", fetch)
+	c.Get("ep", "tokA", "q=1", fetch)
+
+	if calls.Load() != 3 {
+		t.Fatalf("expected distinct (endpoint, token, query) tuples to miss independently, fetch called %d times", calls.Load())
+	}
+}
@@ -0,0 +1,156 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	consulapi "github.com/hashicorp/consul/api"
+)
+
+const sessionTTL = "10s"
+
+// consulRegistry implements Registry on top of a Consul session and
+// session-locked KV keys, Consul's standard leader-election recipe.
+type consulRegistry struct {
+	client        *consulapi.Client
+	sessionID     string
+	prefix        string
+	advertiseAddr string
+
+	mu         sync.Mutex
+	lockedKeys map[string]string
+}
+
+// NewConsulRegistry connects to the given Consul endpoint and registers
+// advertiseAddr under prefix via a TTL'd session.
+func NewConsulRegistry(endpoint, prefix, advertiseAddr string) (Registry, error) {
+	client, err := consulapi.NewClient(&consulapi.Config{Address: endpoint})
+	if err != nil {
+		return nil, fmt.Errorf("consul: connect: %w", err)
+	}
+
+	sessionID, _, err := client.Session().Create(&consulapi.SessionEntry{
+		Name:      "pulse/" + advertiseAddr,
+		TTL:       sessionTTL,
+		Behavior:  consulapi.SessionBehaviorRelease,
+		LockDelay: 1 * time.Second,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("consul: create session: %w", err)
+	}
+
+	memberKey := fmt.Sprintf("%s/members/%s", prefix, advertiseAddr)
+	if _, err := client.KV().Put(&consulapi.KVPair{
+		Key:     memberKey,
+		Value:   []byte(advertiseAddr),
+		Session: sessionID,
+	}, nil); err != nil {
+		return nil, fmt.Errorf("consul: register member: %w", err)
+	}
+
+	go client.Session().RenewPeriodic(sessionTTL, sessionID, nil, nil)
+
+	return &consulRegistry{
+		client:        client,
+		sessionID:     sessionID,
+		prefix:        prefix,
+		advertiseAddr: advertiseAddr,
+		lockedKeys:    make(map[string]string),
+	}, nil
+}
+
+func (r *consulRegistry) Members(ctx context.Context) ([]string, error) {
+	pairs, _, err := r.client.KV().List(r.prefix+"/members/", (&consulapi.QueryOptions{}).WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	members := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		members = append(members, string(pair.Value))
+	}
+	return members, nil
+}
+
+func (r *consulRegistry) Campaign(ctx context.Context, key string) (<-chan struct{}, error) {
+	lockKey := r.prefix + "/elections/" + key
+
+	for {
+		acquired, _, err := r.client.KV().Acquire(&consulapi.KVPair{
+			Key:     lockKey,
+			Value:   []byte(r.advertiseAddr),
+			Session: r.sessionID,
+		}, (&consulapi.WriteOptions{}).WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("consul: acquire lock %s: %w", lockKey, err)
+		}
+		if acquired {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(sessionRetryInterval):
+		}
+	}
+
+	r.mu.Lock()
+	r.lockedKeys[key] = lockKey
+	r.mu.Unlock()
+
+	lost := make(chan struct{})
+	go r.watchSession(lost)
+	return lost, nil
+}
+
+const sessionRetryInterval = 2 * time.Second
+
+// watchSession polls Consul's session info and closes lost once the
+// session this registry's lock is held under disappears (expired, or
+// invalidated by the server).
+func (r *consulRegistry) watchSession(lost chan<- struct{}) {
+	for {
+		time.Sleep(sessionTTLPollInterval)
+		entries, _, err := r.client.Session().Info(r.sessionID, nil)
+		if err != nil || entries == nil {
+			close(lost)
+			return
+		}
+	}
+}
+
+const sessionTTLPollInterval = 3 * time.Second
+
+func (r *consulRegistry) Resign(ctx context.Context, key string) error {
+	r.mu.Lock()
+	lockKey, ok := r.lockedKeys[key]
+	delete(r.lockedKeys, key)
+	r.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	_, _, err := r.client.KV().Release(&consulapi.KVPair{
+		Key:     lockKey,
+		Session: r.sessionID,
+	}, (&consulapi.WriteOptions{}).WithContext(ctx))
+	return err
+}
+
+func (r *consulRegistry) Leader(ctx context.Context, key string) (string, error) {
+	lockKey := r.prefix + "/elections/" + key
+	pair, _, err := r.client.KV().Get(lockKey, (&consulapi.QueryOptions{}).WithContext(ctx))
+	if err != nil {
+		return "", fmt.Errorf("consul: leader for %s: %w", key, err)
+	}
+	if pair == nil || pair.Session == "" {
+		return "", nil
+	}
+	return string(pair.Value), nil
+}
+
+func (r *consulRegistry) Close() error {
+	_, err := r.client.Session().Destroy(r.sessionID, nil)
+	return err
+}
@@ -0,0 +1,118 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
+)
+
+const leaseTTLSeconds = 10
+
+// etcdRegistry implements Registry on top of an etcd lease and the
+// concurrency package's session-scoped mutex/election primitives.
+type etcdRegistry struct {
+	client        *clientv3.Client
+	session       *concurrency.Session
+	prefix        string
+	advertiseAddr string
+
+	mu        sync.Mutex
+	elections map[string]*concurrency.Election
+}
+
+// NewEtcdRegistry connects to the given etcd endpoints and registers
+// advertiseAddr under prefix via a TTL'd lease/session.
+func NewEtcdRegistry(endpoints []string, prefix, advertiseAddr string) (Registry, error) {
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   endpoints,
+		DialTimeout: 5 * time.Second,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("etcd: connect: %w", err)
+	}
+
+	session, err := concurrency.NewSession(client, concurrency.WithTTL(leaseTTLSeconds))
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("etcd: create session: %w", err)
+	}
+
+	memberKey := fmt.Sprintf("%s/members/%s", prefix, advertiseAddr)
+	if _, err := client.Put(context.Background(), memberKey, advertiseAddr, clientv3.WithLease(session.Lease())); err != nil {
+		session.Close()
+		client.Close()
+		return nil, fmt.Errorf("etcd: register member: %w", err)
+	}
+
+	return &etcdRegistry{
+		client:        client,
+		session:       session,
+		prefix:        prefix,
+		advertiseAddr: advertiseAddr,
+		elections:     make(map[string]*concurrency.Election),
+	}, nil
+}
+
+func (r *etcdRegistry) Members(ctx context.Context) ([]string, error) {
+	resp, err := r.client.Get(ctx, r.prefix+"/members/", clientv3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+	members := make([]string, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		members = append(members, string(kv.Value))
+	}
+	return members, nil
+}
+
+func (r *etcdRegistry) Campaign(ctx context.Context, key string) (<-chan struct{}, error) {
+	election := concurrency.NewElection(r.session, r.prefix+"/elections/"+key)
+	r.mu.Lock()
+	r.elections[key] = election
+	r.mu.Unlock()
+
+	if err := election.Campaign(ctx, r.advertiseAddr); err != nil {
+		return nil, fmt.Errorf("etcd: campaign for %s: %w", key, err)
+	}
+
+	lost := make(chan struct{})
+	go func() {
+		<-r.session.Done()
+		close(lost)
+	}()
+	return lost, nil
+}
+
+func (r *etcdRegistry) Resign(ctx context.Context, key string) error {
+	r.mu.Lock()
+	election, ok := r.elections[key]
+	r.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return election.Resign(ctx)
+}
+
+func (r *etcdRegistry) Leader(ctx context.Context, key string) (string, error) {
+	election := concurrency.NewElection(r.session, r.prefix+"/elections/"+key)
+	resp, err := election.Leader(ctx)
+	if err == concurrency.ErrElectionNoLeader {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("etcd: leader for %s: %w", key, err)
+	}
+	if len(resp.Kvs) == 0 {
+		return "", nil
+	}
+	return string(resp.Kvs[0].Value), nil
+}
+
+func (r *etcdRegistry) Close() error {
+	r.session.Close()
+	return r.client.Close()
+}
@@ -0,0 +1,125 @@
+package cluster
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// campaignRetryDelay is how long Campaign waits after a failed election
+// attempt before retrying, so a Consul/etcd blip doesn't turn into a tight
+// busy-loop hammering the coordination backend.
+const campaignRetryDelay = 2 * time.Second
+
+// Manager owns this instance's membership in the cluster and decides, per
+// monitored PVE cluster, whether this instance is the leader (and should
+// poll + replicate state) or a follower (and should shard out whichever
+// nodes rendezvous hashing assigns to it, serving only read-only traffic
+// from the leader's replicated stream).
+type Manager struct {
+	registry      Registry
+	advertiseAddr string
+
+	mu        sync.RWMutex
+	isLeader  map[string]bool
+	onElected func(pveCluster string)
+	onDemoted func(pveCluster string)
+}
+
+// NewManager wraps a Registry. onElected/onDemoted fire when this
+// instance's leadership of a given PVE cluster ID changes.
+func NewManager(registry Registry, advertiseAddr string, onElected, onDemoted func(pveCluster string)) *Manager {
+	return &Manager{
+		registry:      registry,
+		advertiseAddr: advertiseAddr,
+		isLeader:      make(map[string]bool),
+		onElected:     onElected,
+		onDemoted:     onDemoted,
+	}
+}
+
+// Campaign runs the leader election for pveCluster in the background,
+// invoking onElected/onDemoted as leadership is won and lost. It retries
+// indefinitely until ctx is cancelled.
+func (m *Manager) Campaign(ctx context.Context, pveCluster string) {
+	go func() {
+		for {
+			if ctx.Err() != nil {
+				return
+			}
+
+			lost, err := m.registry.Campaign(ctx, pveCluster)
+			if err != nil {
+				log.Warn().Err(err).Str("cluster", pveCluster).Msg("cluster: campaign failed, retrying")
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(campaignRetryDelay):
+				}
+				continue
+			}
+
+			m.setLeader(pveCluster, true)
+			log.Info().Str("cluster", pveCluster).Msg("cluster: elected leader")
+
+			select {
+			case <-ctx.Done():
+				m.registry.Resign(context.Background(), pveCluster)
+				return
+			case <-lost:
+				m.setLeader(pveCluster, false)
+				log.Warn().Str("cluster", pveCluster).Msg("cluster: lost leadership, re-campaigning")
+			}
+		}
+	}()
+}
+
+func (m *Manager) setLeader(pveCluster string, leader bool) {
+	m.mu.Lock()
+	m.isLeader[pveCluster] = leader
+	m.mu.Unlock()
+
+	if leader && m.onElected != nil {
+		m.onElected(pveCluster)
+	}
+	if !leader && m.onDemoted != nil {
+		m.onDemoted(pveCluster)
+	}
+}
+
+// IsLeader reports whether this instance currently holds leadership for
+// pveCluster.
+func (m *Manager) IsLeader(pveCluster string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.isLeader[pveCluster]
+}
+
+// OwnsNode reports whether this instance is responsible for polling nodeID
+// as a follower, per rendezvous hashing over the current member list. The
+// leader for the node's PVE cluster always owns all of its own polling;
+// this is only consulted when this instance is not the leader.
+func (m *Manager) OwnsNode(ctx context.Context, nodeID string) (bool, error) {
+	members, err := m.registry.Members(ctx)
+	if err != nil {
+		return false, err
+	}
+	if len(members) == 0 {
+		return true, nil
+	}
+	return PickOwner(nodeID, members) == m.advertiseAddr, nil
+}
+
+// LeaderAddr returns the advertise address of whichever instance currently
+// holds leadership for pveCluster, or "" if nobody does yet. Followers use
+// this to find where to dial their ReplicaClient.
+func (m *Manager) LeaderAddr(ctx context.Context, pveCluster string) (string, error) {
+	return m.registry.Leader(ctx, pveCluster)
+}
+
+// Close releases the registry's underlying session/connection.
+func (m *Manager) Close() error {
+	return m.registry.Close()
+}
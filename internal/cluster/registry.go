@@ -0,0 +1,41 @@
+// Package cluster lets multiple Pulse instances run against the same set
+// of Proxmox clusters behind a load balancer without duplicating polling
+// or duplicating alerts: one instance is elected leader per monitored PVE
+// cluster, and polling work is sharded across the rest via rendezvous
+// hashing.
+package cluster
+
+import "context"
+
+// Registry abstracts the coordination backend (Consul or etcd) used for
+// membership and leader election.
+type Registry interface {
+	// Members returns the advertise addresses of every live instance
+	// registered under the configured prefix.
+	Members(ctx context.Context) ([]string, error)
+
+	// Campaign blocks until this instance wins the leader lock for key, or
+	// ctx is cancelled. On success it returns a channel that is closed the
+	// moment leadership is lost (session expiry, network partition, etc.)
+	// so the caller can step down.
+	Campaign(ctx context.Context, key string) (lost <-chan struct{}, err error)
+
+	// Resign releases leadership of key if this instance currently holds
+	// it. It is a no-op otherwise.
+	Resign(ctx context.Context, key string) error
+
+	// Leader returns the advertise address of whichever instance currently
+	// holds the leader lock for key, or "" if nobody holds it yet.
+	Leader(ctx context.Context, key string) (string, error)
+
+	// Close releases the session/lease and any background connections.
+	Close() error
+}
+
+// Backend names a supported coordination backend.
+type Backend string
+
+const (
+	BackendConsul Backend = "consul"
+	BackendEtcd   Backend = "etcd"
+)
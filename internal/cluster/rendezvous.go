@@ -0,0 +1,31 @@
+package cluster
+
+import "hash/fnv"
+
+// PickOwner applies rendezvous (highest random weight) hashing to decide
+// which member of members is responsible for polling the given node ID.
+// Every instance in the cluster computes this independently and arrives at
+// the same answer without any coordination beyond knowing the member list,
+// and only the entries that map to an added/removed member reshuffle when
+// membership changes.
+func PickOwner(nodeID string, members []string) string {
+	var best string
+	var bestWeight uint64
+
+	for _, member := range members {
+		w := rendezvousWeight(nodeID, member)
+		if w > bestWeight || (w == bestWeight && member < best) {
+			bestWeight = w
+			best = member
+		}
+	}
+	return best
+}
+
+func rendezvousWeight(nodeID, member string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(nodeID))
+	h.Write([]byte{0})
+	h.Write([]byte(member))
+	return h.Sum64()
+}
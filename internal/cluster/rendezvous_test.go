@@ -0,0 +1,68 @@
+package cluster
+
+import "testing"
+
+func TestPickOwnerDeterministic(t *testing.T) {
+	members := []string{"10.0.0.1:8080", "10.0.0.2:8080", "10.0.0.3:8080"}
+
+	want := PickOwner("pve-node-1", members)
+	for i := 0; i < 50; i++ {
+		if got := PickOwner("pve-node-1", members); got != want {
+			t.Fatalf("PickOwner returned %q, want %q (not deterministic)", got, want)
+		}
+	}
+}
+
+func TestPickOwnerDistributesAcrossMembers(t *testing.T) {
+	members := []string{"a", "b", "c"}
+	nodes := []string{"node-1", "node-2", "node-3", "node-4", "node-5", "node-6"}
+
+	owners := make(map[string]int)
+	for _, n := range nodes {
+		owners[PickOwner(n, members)]++
+	}
+	if len(owners) < 2 {
+		t.Fatalf("expected ownership to spread across members, got %v", owners)
+	}
+	for owner := range owners {
+		found := false
+		for _, m := range members {
+			if m == owner {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("PickOwner returned %q, not a member of %v", owner, members)
+		}
+	}
+}
+
+func TestPickOwnerStableUnderMemberRemoval(t *testing.T) {
+	full := []string{"a", "b", "c", "d"}
+	nodes := []string{"node-1", "node-2", "node-3", "node-4", "node-5", "node-6", "node-7", "node-8"}
+
+	before := make(map[string]string, len(nodes))
+	for _, n := range nodes {
+		before[n] = PickOwner(n, full)
+	}
+
+	reduced := []string{"a", "b", "c"}
+	reassigned := 0
+	for _, n := range nodes {
+		if before[n] == "d" {
+			continue
+		}
+		if PickOwner(n, reduced) != before[n] {
+			reassigned++
+		}
+	}
+	if reassigned != 0 {
+		t.Fatalf("removing an unrelated member reshuffled %d nodes that weren't owned by it", reassigned)
+	}
+}
+
+func TestPickOwnerEmptyMembers(t *testing.T) {
+	if got := PickOwner("node-1", nil); got != "" {
+		t.Fatalf("PickOwner with no members = %q, want empty string", got)
+	}
+}
@@ -0,0 +1,125 @@
+package cluster
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog/log"
+)
+
+// Snapshot is the replicated application state a leader pushes to its
+// followers. It is intentionally an opaque blob (JSON-encoded by the
+// caller) so this package doesn't need to know about guest/node/alert
+// models directly.
+type Snapshot struct {
+	Version uint64    `json:"version"`
+	Data    []byte    `json:"data"`
+	SentAt  time.Time `json:"sent_at"`
+}
+
+var replicationUpgrader = websocket.Upgrader{
+	ReadBufferSize:  4096,
+	WriteBufferSize: 4096,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// ReplicationServer runs on the leader and fans out Snapshots pushed via
+// Publish to every connected follower.
+type ReplicationServer struct {
+	mu    sync.Mutex
+	conns map[*websocket.Conn]struct{}
+}
+
+// NewReplicationServer creates an empty ReplicationServer.
+func NewReplicationServer() *ReplicationServer {
+	return &ReplicationServer{conns: make(map[*websocket.Conn]struct{})}
+}
+
+// ServeHTTP accepts a follower's replication connection.
+func (s *ReplicationServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := replicationUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Warn().Err(err).Msg("cluster: replication upgrade failed")
+		return
+	}
+
+	s.mu.Lock()
+	s.conns[conn] = struct{}{}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		conn.Close()
+	}()
+
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+// Publish pushes a Snapshot to every connected follower.
+func (s *ReplicationServer) Publish(snap Snapshot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for conn := range s.conns {
+		if err := conn.WriteJSON(snap); err != nil {
+			log.Debug().Err(err).Msg("cluster: replication push failed, dropping follower")
+		}
+	}
+}
+
+// ReplicaClient runs on a follower and keeps the latest Snapshot received
+// from the leader available to the local read-only API.
+type ReplicaClient struct {
+	latest atomic.Pointer[Snapshot]
+}
+
+// NewReplicaClient dials leaderURL (a ws:// or wss:// URL) and keeps
+// reconnecting in the background until ctx is cancelled.
+func NewReplicaClient(ctx context.Context, leaderURL string) *ReplicaClient {
+	c := &ReplicaClient{}
+	go c.run(ctx, leaderURL)
+	return c
+}
+
+func (c *ReplicaClient) run(ctx context.Context, leaderURL string) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		conn, _, err := websocket.DefaultDialer.DialContext(ctx, leaderURL, nil)
+		if err != nil {
+			log.Warn().Err(err).Str("leader", leaderURL).Msg("cluster: replication dial failed, retrying")
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(2 * time.Second):
+				continue
+			}
+		}
+
+		for {
+			var snap Snapshot
+			if err := conn.ReadJSON(&snap); err != nil {
+				conn.Close()
+				break
+			}
+			c.latest.Store(&snap)
+		}
+	}
+}
+
+// Latest returns the most recently replicated Snapshot, or nil if none has
+// arrived yet.
+func (c *ReplicaClient) Latest() *Snapshot {
+	return c.latest.Load()
+}
@@ -0,0 +1,162 @@
+// Package config loads Pulse's YAML/env configuration and exposes it to the
+// rest of the application.
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+
+	"github.com/rcourtman/pulse-go-rewrite/internal/secret"
+)
+
+// ProxmoxNode describes a single PVE or PBS endpoint Pulse polls. Token is
+// a secret.Secret, not a string, so it never ends up in a heap dump, log
+// line, or JSON response.
+type ProxmoxNode struct {
+	Name     string        `yaml:"name"`
+	Addr     string        `yaml:"addr" reload:"restart"`
+	Token    secret.Secret `yaml:"token"`
+	Insecure bool          `yaml:"insecure"`
+}
+
+// LoggingConfig configures where and how Pulse writes its zerolog output.
+type LoggingConfig struct {
+	Level   string            `yaml:"level"`
+	Console bool              `yaml:"console"`
+	File    *FileSinkConfig   `yaml:"file"`
+	Syslog  *SyslogSinkConfig `yaml:"syslog"`
+}
+
+// FileSinkConfig enables a rotating file sink backed by lumberjack.
+type FileSinkConfig struct {
+	Path       string `yaml:"path" reload:"restart"`
+	MaxSizeMB  int    `yaml:"max_size_mb"`
+	MaxBackups int    `yaml:"max_backups"`
+	MaxAgeDays int    `yaml:"max_age_days"`
+	Compress   bool   `yaml:"compress"`
+}
+
+// SyslogSinkConfig enables forwarding log output to syslog/journald.
+type SyslogSinkConfig struct {
+	Network string `yaml:"network" reload:"restart"` // "" for local, "tcp"/"udp" for remote
+	Addr    string `yaml:"addr" reload:"restart"`
+	Tag     string `yaml:"tag"`
+}
+
+// SearchConfig configures the embedded event/alert history index.
+type SearchConfig struct {
+	RetentionDays int `yaml:"retention_days"`
+}
+
+// CacheTTLOverride lets an operator trade staleness for reduced PVE load on
+// a specific endpoint (e.g. a slow-changing storage listing).
+type CacheTTLOverride struct {
+	Endpoint string `yaml:"endpoint"`
+	FreshSec int    `yaml:"fresh_seconds"`
+	StaleSec int    `yaml:"stale_seconds"`
+}
+
+// CacheConfig configures the shared response cache sitting between the
+// Proxmox HTTP client and the collectors.
+type CacheConfig struct {
+	MaxEntries   int                `yaml:"max_entries"`
+	FreshSeconds int                `yaml:"fresh_seconds"`
+	StaleSeconds int                `yaml:"stale_seconds"`
+	Overrides    []CacheTTLOverride `yaml:"overrides"`
+}
+
+// ClusterConfig enables HA clustering across multiple Pulse instances.
+// When absent, Pulse runs as a standalone single-node deployment exactly
+// as before.
+type ClusterConfig struct {
+	Backend       string   `yaml:"backend"` // "consul" or "etcd"
+	Endpoints     []string `yaml:"endpoints"`
+	Prefix        string   `yaml:"prefix"`
+	AdvertiseAddr string   `yaml:"advertise_addr" reload:"restart"`
+}
+
+// Enabled reports whether a cluster block was configured at all.
+func (c ClusterConfig) Enabled() bool {
+	return c.Backend != ""
+}
+
+// Config is the root configuration document, loaded from the YAML file
+// referenced by PULSE_CONFIG_FILE (or ./config.yaml by default) and
+// overlaid with environment variables loaded via godotenv.
+type Config struct {
+	ListenAddr string        `yaml:"listen_addr" reload:"restart"`
+	DataDir    string        `yaml:"data_dir" reload:"restart"`
+	LogLevel   string        `yaml:"log_level"`
+	Logging    LoggingConfig `yaml:"logging"`
+	Search     SearchConfig  `yaml:"search"`
+	Cluster    ClusterConfig `yaml:"cluster"`
+	Cache      CacheConfig   `yaml:"cache"`
+	Nodes      []ProxmoxNode `yaml:"nodes"`
+}
+
+// Load reads and parses the config file at path, then applies any
+// PULSE_-prefixed environment variables found in .env via godotenv.
+func Load(path string) (*Config, error) {
+	_ = godotenv.Load()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config %s: %w", path, err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse config %s: %w", path, err)
+	}
+
+	if cfg.ListenAddr == "" {
+		cfg.ListenAddr = ":7655"
+	}
+	if cfg.DataDir == "" {
+		cfg.DataDir = "./data"
+	}
+	if cfg.Search.RetentionDays == 0 {
+		cfg.Search.RetentionDays = 90
+	}
+	if cfg.Cache.MaxEntries == 0 {
+		cfg.Cache.MaxEntries = 1024
+	}
+	if cfg.Cache.FreshSeconds == 0 {
+		cfg.Cache.FreshSeconds = 15
+	}
+	if cfg.Cache.StaleSeconds == 0 {
+		cfg.Cache.StaleSeconds = 30
+	}
+
+	applyTokenEnvOverrides(&cfg)
+
+	return &cfg, nil
+}
+
+// applyTokenEnvOverrides lets an operator keep tokens out of the YAML file
+// entirely: PULSE_TOKEN_<NODE_NAME> (upper-cased, non-alphanumerics turned
+// into underscores) overrides that node's token if set.
+func applyTokenEnvOverrides(cfg *Config) {
+	for i, node := range cfg.Nodes {
+		envName := "PULSE_TOKEN_" + envSafe(node.Name)
+		if v, ok := os.LookupEnv(envName); ok {
+			cfg.Nodes[i].Token = secret.FromString(v)
+		}
+	}
+}
+
+func envSafe(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(name) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
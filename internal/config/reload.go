@@ -0,0 +1,232 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog/log"
+)
+
+// debounceWindow coalesces the burst of fsnotify events a single `mv`/`cp`
+// into place tends to generate.
+const debounceWindow = 200 * time.Millisecond
+
+// ReloadNotifier receives a notification each time the Store is swapped in
+// successfully. The HTTP/WebSocket layer implements this to push a
+// config.reloaded event to connected clients.
+type ReloadNotifier interface {
+	Broadcast(eventType string, data any)
+}
+
+// Watcher observes the directory containing a config file and reloads it
+// into a Store whenever the file changes on disk.
+type Watcher struct {
+	path     string
+	store    *Store
+	notifier ReloadNotifier
+	watcher  *fsnotify.Watcher
+	done     chan struct{}
+}
+
+// WatchFile starts watching the directory containing path and applies
+// validated changes to store as they land. notifier may be nil. Call Close
+// to stop watching.
+func WatchFile(path string, store *Store, notifier ReloadNotifier) (*Watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Dir(path)
+	if err := fsw.Add(dir); err != nil {
+		fsw.Close()
+		return nil, err
+	}
+
+	w := &Watcher{
+		path:     path,
+		store:    store,
+		notifier: notifier,
+		watcher:  fsw,
+		done:     make(chan struct{}),
+	}
+	go w.run()
+	return w, nil
+}
+
+// Close stops the watcher and releases the underlying inotify handle.
+func (w *Watcher) Close() error {
+	close(w.done)
+	return w.watcher.Close()
+}
+
+func (w *Watcher) run() {
+	var timer *time.Timer
+	var debounceC <-chan time.Time
+
+	for {
+		select {
+		case <-w.done:
+			return
+
+		case ev, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(ev.Name) != filepath.Clean(w.path) {
+				continue
+			}
+			if timer == nil {
+				timer = time.NewTimer(debounceWindow)
+				debounceC = timer.C
+			} else {
+				if !timer.Stop() {
+					<-timer.C
+				}
+				timer.Reset(debounceWindow)
+			}
+
+		case <-debounceC:
+			debounceC = nil
+			w.reload()
+
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Warn().Err(err).Msg("config watcher error")
+		}
+	}
+}
+
+func (w *Watcher) reload() {
+	next, err := Load(w.path)
+	if err != nil {
+		log.Warn().Err(err).Str("path", w.path).Msg("config reload: invalid document, keeping previous config")
+		return
+	}
+
+	prev := w.store.swap(next)
+	log.Info().Str("event", "config.reloaded").Str("path", w.path).Msg("configuration reloaded")
+
+	restartFields := restartOnlyDiffs(prev, next)
+	for _, field := range restartFields {
+		log.Warn().Str("field", field).Msg("config field changed but requires a restart to take effect")
+	}
+
+	if w.notifier != nil {
+		w.notifier.Broadcast("config.reloaded", map[string]any{
+			"restart_required": restartFields,
+		})
+	}
+}
+
+// restartOnlyDiffs walks prev and next field by field, recursing into
+// nested structs, pointers-to-structs, and slices of structs, and returns
+// a dotted path (e.g. "nodes[0].addr" or "logging.file.path") for every
+// field tagged `reload:"restart"` whose value changed. Such fields are
+// applied to the Store like everything else (so a restart picks them up)
+// but cannot take effect on a running process, so callers surface them as
+// warnings instead of silently ignoring the discrepancy.
+func restartOnlyDiffs(prev, next *Config) []string {
+	if prev == nil || next == nil {
+		return nil
+	}
+	return diffRestartFields("", reflect.ValueOf(*prev), reflect.ValueOf(*next))
+}
+
+// diffRestartFields compares the struct values pv and nv field by field,
+// prefixing any reported path with path (already-dotted, or "" at the
+// root). It descends into nested structs, pointer-to-struct fields (a nil
+// pointer is treated as its zero value so a nil<->populated transition
+// still reports any restart-tagged fields inside it), and slices of
+// structs (comparing by index, padding the shorter slice with zero
+// values). Unexported fields are skipped, since reflect cannot read them
+// and some embedded types (e.g. secret.Secret) rely on that invariant.
+func diffRestartFields(path string, pv, nv reflect.Value) []string {
+	pv = derefOrZero(pv)
+	nv = derefOrZero(nv)
+	if pv.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var diffs []string
+	t := pv.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := field.Tag.Get("yaml")
+		if name == "" {
+			name = field.Name
+		}
+		fieldPath := name
+		if path != "" {
+			fieldPath = path + "." + name
+		}
+
+		fpv, fnv := pv.Field(i), nv.Field(i)
+		switch {
+		case fpv.Kind() == reflect.Struct:
+			diffs = append(diffs, diffRestartFields(fieldPath, fpv, fnv)...)
+			continue
+		case fpv.Kind() == reflect.Ptr && fpv.Type().Elem().Kind() == reflect.Struct:
+			diffs = append(diffs, diffRestartFields(fieldPath, fpv, fnv)...)
+			continue
+		case fpv.Kind() == reflect.Slice && fpv.Type().Elem().Kind() == reflect.Struct:
+			diffs = append(diffs, diffRestartSlice(fieldPath, fpv, fnv)...)
+			continue
+		}
+
+		if field.Tag.Get("reload") != "restart" {
+			continue
+		}
+		if !reflect.DeepEqual(fpv.Interface(), fnv.Interface()) {
+			diffs = append(diffs, fieldPath)
+		}
+	}
+	return diffs
+}
+
+// diffRestartSlice compares slices of structs element by element, padding
+// whichever of pv/nv is shorter with zero values so added/removed trailing
+// elements are still diffed against an empty baseline.
+func diffRestartSlice(path string, pv, nv reflect.Value) []string {
+	n := pv.Len()
+	if nv.Len() > n {
+		n = nv.Len()
+	}
+
+	var diffs []string
+	elemType := pv.Type().Elem()
+	for i := 0; i < n; i++ {
+		pe, ne := reflect.New(elemType).Elem(), reflect.New(elemType).Elem()
+		if i < pv.Len() {
+			pe = pv.Index(i)
+		}
+		if i < nv.Len() {
+			ne = nv.Index(i)
+		}
+		diffs = append(diffs, diffRestartFields(fmt.Sprintf("%s[%d]", path, i), pe, ne)...)
+	}
+	return diffs
+}
+
+// derefOrZero dereferences a pointer value, substituting a zero value of
+// the pointee type when v is nil so comparisons against a populated
+// pointer on the other side still walk into the struct's fields.
+func derefOrZero(v reflect.Value) reflect.Value {
+	if v.Kind() != reflect.Ptr {
+		return v
+	}
+	if v.IsNil() {
+		return reflect.New(v.Type().Elem()).Elem()
+	}
+	return v.Elem()
+}
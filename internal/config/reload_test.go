@@ -0,0 +1,110 @@
+package config
+
+import (
+	"sort"
+	"testing"
+)
+
+func baseConfig() *Config {
+	return &Config{
+		ListenAddr: ":7655",
+		DataDir:    "./data",
+		Logging: LoggingConfig{
+			Level: "info",
+			File:  &FileSinkConfig{Path: "/var/log/pulse.log"},
+		},
+		Cluster: ClusterConfig{Backend: "consul", AdvertiseAddr: "10.0.0.1:7655"},
+		Nodes: []ProxmoxNode{
+			{Name: "pve1", Addr: "https://10.0.0.1:8006"},
+		},
+	}
+}
+
+func TestRestartOnlyDiffsNoChanges(t *testing.T) {
+	prev := baseConfig()
+	next := baseConfig()
+
+	if diffs := restartOnlyDiffs(prev, next); diffs != nil {
+		t.Fatalf("expected no diffs for identical configs, got %v", diffs)
+	}
+}
+
+func TestRestartOnlyDiffsIgnoresNonRestartFields(t *testing.T) {
+	prev := baseConfig()
+	next := baseConfig()
+	next.LogLevel = "debug"
+	next.Logging.Level = "debug"
+	next.Nodes[0].Insecure = true
+
+	if diffs := restartOnlyDiffs(prev, next); diffs != nil {
+		t.Fatalf("expected no diffs for non-restart fields, got %v", diffs)
+	}
+}
+
+func TestRestartOnlyDiffsTopLevelField(t *testing.T) {
+	prev := baseConfig()
+	next := baseConfig()
+	next.ListenAddr = ":9999"
+
+	diffs := restartOnlyDiffs(prev, next)
+	assertDiffs(t, diffs, "listen_addr")
+}
+
+func TestRestartOnlyDiffsNestedSliceField(t *testing.T) {
+	prev := baseConfig()
+	next := baseConfig()
+	next.Nodes[0].Addr = "https://10.0.0.2:8006"
+
+	diffs := restartOnlyDiffs(prev, next)
+	assertDiffs(t, diffs, "nodes[0].addr")
+}
+
+func TestRestartOnlyDiffsNestedPointerField(t *testing.T) {
+	prev := baseConfig()
+	next := baseConfig()
+	next.Logging.File = &FileSinkConfig{Path: "/var/log/pulse-new.log"}
+
+	diffs := restartOnlyDiffs(prev, next)
+	assertDiffs(t, diffs, "logging.file.path")
+}
+
+func TestRestartOnlyDiffsNilPointerTransition(t *testing.T) {
+	prev := baseConfig()
+	prev.Logging.File = nil
+	next := baseConfig()
+
+	diffs := restartOnlyDiffs(prev, next)
+	assertDiffs(t, diffs, "logging.file.path")
+}
+
+func TestRestartOnlyDiffsNestedStructField(t *testing.T) {
+	prev := baseConfig()
+	next := baseConfig()
+	next.Cluster.AdvertiseAddr = "10.0.0.2:7655"
+
+	diffs := restartOnlyDiffs(prev, next)
+	assertDiffs(t, diffs, "cluster.advertise_addr")
+}
+
+func TestRestartOnlyDiffsNilConfig(t *testing.T) {
+	if diffs := restartOnlyDiffs(nil, baseConfig()); diffs != nil {
+		t.Fatalf("expected nil diffs when prev is nil, got %v", diffs)
+	}
+	if diffs := restartOnlyDiffs(baseConfig(), nil); diffs != nil {
+		t.Fatalf("expected nil diffs when next is nil, got %v", diffs)
+	}
+}
+
+func assertDiffs(t *testing.T, got []string, want ...string) {
+	t.Helper()
+	sort.Strings(got)
+	sort.Strings(want)
+	if len(got) != len(want) {
+		t.Fatalf("diffs = %v, want %v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("diffs = %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package config
+
+import "sync/atomic"
+
+// Store holds the current Config behind an atomic pointer so the HTTP and
+// WebSocket layers can read a consistent snapshot while a reload swaps in a
+// new one concurrently.
+type Store struct {
+	current atomic.Pointer[Config]
+}
+
+// NewStore creates a Store seeded with the given Config.
+func NewStore(cfg *Config) *Store {
+	s := &Store{}
+	s.current.Store(cfg)
+	return s
+}
+
+// Get returns the currently active Config. Callers must not mutate it.
+func (s *Store) Get() *Config {
+	return s.current.Load()
+}
+
+// swap atomically replaces the active Config and returns the previous one.
+func (s *Store) swap(cfg *Config) *Config {
+	return s.current.Swap(cfg)
+}
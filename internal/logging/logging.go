@@ -0,0 +1,80 @@
+// Package logging builds Pulse's zerolog.Logger from configuration,
+// supporting size/time-based file rotation and multiple simultaneous sinks.
+package logging
+
+import (
+	"io"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/rcourtman/pulse-go-rewrite/internal/config"
+)
+
+// Logger wraps a zerolog.Logger along with the rotating file sink (if any)
+// so the caller can trigger a reopen on SIGHUP.
+type Logger struct {
+	zerolog.Logger
+	file *lumberjack.Logger
+}
+
+// Build constructs a Logger from cfg. At least one sink is always present;
+// if none are configured it falls back to stdout.
+func Build(cfg config.LoggingConfig) (*Logger, error) {
+	level, err := zerolog.ParseLevel(cfg.Level)
+	if err != nil || cfg.Level == "" {
+		level = zerolog.InfoLevel
+	}
+
+	var writers []io.Writer
+	var fileSink *lumberjack.Logger
+
+	if cfg.Console || cfg.File == nil {
+		writers = append(writers, zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: time.RFC3339})
+	}
+
+	if cfg.File != nil {
+		fileSink = &lumberjack.Logger{
+			Filename:   cfg.File.Path,
+			MaxSize:    cfg.File.MaxSizeMB,
+			MaxBackups: cfg.File.MaxBackups,
+			MaxAge:     cfg.File.MaxAgeDays,
+			Compress:   cfg.File.Compress,
+		}
+		writers = append(writers, fileSink)
+	}
+
+	if cfg.Syslog != nil {
+		sw, err := newSyslogWriter(*cfg.Syslog)
+		if err != nil {
+			return nil, err
+		}
+		writers = append(writers, sw)
+	}
+
+	var out io.Writer
+	switch len(writers) {
+	case 0:
+		out = os.Stdout
+	case 1:
+		out = writers[0]
+	default:
+		out = zerolog.MultiLevelWriter(writers...)
+	}
+
+	logger := zerolog.New(out).Level(level).With().Timestamp().Logger()
+	return &Logger{Logger: logger, file: fileSink}, nil
+}
+
+// Reopen rotates the underlying log file, closing the current one and
+// starting a fresh one at the same path. This mirrors what `logrotate`'s
+// `copytruncate`-free `postrotate` hooks expect: send SIGHUP, the process
+// reopens its file handle instead of writing to the now-renamed inode.
+func (l *Logger) Reopen() error {
+	if l.file == nil {
+		return nil
+	}
+	return l.file.Rotate()
+}
@@ -0,0 +1,24 @@
+//go:build !windows
+
+package logging
+
+import (
+	"io"
+	"log/syslog"
+
+	"github.com/rcourtman/pulse-go-rewrite/internal/config"
+)
+
+// newSyslogWriter dials the local syslog daemon (which on most Linux
+// distributions forwards straight into journald) or a remote one when
+// Network/Addr are set.
+func newSyslogWriter(cfg config.SyslogSinkConfig) (io.Writer, error) {
+	tag := cfg.Tag
+	if tag == "" {
+		tag = "pulse"
+	}
+	if cfg.Addr != "" {
+		return syslog.Dial(cfg.Network, cfg.Addr, syslog.LOG_INFO|syslog.LOG_DAEMON, tag)
+	}
+	return syslog.New(syslog.LOG_INFO|syslog.LOG_DAEMON, tag)
+}
@@ -0,0 +1,16 @@
+//go:build windows
+
+package logging
+
+import (
+	"errors"
+	"io"
+
+	"github.com/rcourtman/pulse-go-rewrite/internal/config"
+)
+
+// newSyslogWriter is unsupported on Windows; there is no local syslog/journald
+// daemon to forward to.
+func newSyslogWriter(cfg config.SyslogSinkConfig) (io.Writer, error) {
+	return nil, errors.New("logging: syslog sink is not supported on windows")
+}
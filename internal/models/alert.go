@@ -0,0 +1,36 @@
+// Package models holds the shared data types exchanged between the
+// collectors, the HTTP/WebSocket API, and the persistence layers.
+package models
+
+import "time"
+
+// AlertSeverity classifies how urgently an alert needs attention.
+type AlertSeverity string
+
+const (
+	SeverityInfo     AlertSeverity = "info"
+	SeverityWarning  AlertSeverity = "warning"
+	SeverityCritical AlertSeverity = "critical"
+)
+
+// AlertKind identifies which subsystem raised the alert.
+type AlertKind string
+
+const (
+	AlertKindGuest   AlertKind = "guest"
+	AlertKindNode    AlertKind = "node"
+	AlertKindStorage AlertKind = "storage"
+	AlertKindBackup  AlertKind = "backup"
+)
+
+// Alert represents a single state transition raised by a collector, e.g. a
+// guest going offline or a backup job failing.
+type Alert struct {
+	ID        string        `json:"id"`
+	Timestamp time.Time     `json:"timestamp"`
+	Node      string        `json:"node"`
+	Guest     string        `json:"guest,omitempty"`
+	Severity  AlertSeverity `json:"severity"`
+	Kind      AlertKind     `json:"kind"`
+	Message   string        `json:"message"`
+}
@@ -0,0 +1,100 @@
+// Package pve is a thin HTTP client for the Proxmox VE and PBS REST APIs.
+package pve
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/rcourtman/pulse-go-rewrite/internal/cache"
+	"github.com/rcourtman/pulse-go-rewrite/internal/config"
+)
+
+// Client talks to a single PVE/PBS endpoint using its configured API token.
+// Responses are served through a shared Cache so collectors polling on
+// tight intervals don't hammer the same slow-changing endpoints every
+// cycle.
+type Client struct {
+	addr      string
+	node      config.ProxmoxNode
+	http      *http.Client
+	cache     *cache.Cache
+	tokenHash string
+}
+
+// NewClient builds a Client for node. sharedCache may be nil, in which
+// case every Get bypasses caching entirely.
+func NewClient(node config.ProxmoxNode, sharedCache *cache.Cache) *Client {
+	c := &Client{
+		addr:  node.Addr,
+		node:  node,
+		cache: sharedCache,
+		http: &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: node.Insecure},
+			},
+		},
+	}
+
+	node.Token.Open(func(plaintext []byte) error {
+		c.tokenHash = cache.KeyHashToken(plaintext)
+		return nil
+	})
+
+	return c
+}
+
+// newRequest builds an http.Request against the node's API with the
+// Authorization header set from the locked token buffer. The plaintext
+// token only exists for the duration of the Secret.Open callback.
+func (c *Client) newRequest(method, path string) (*http.Request, error) {
+	req, err := http.NewRequest(method, c.addr+path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.node.Token.Open(func(plaintext []byte) error {
+		if len(plaintext) == 0 {
+			return nil
+		}
+		req.Header.Set("Authorization", fmt.Sprintf("PVEAPIToken=%s", plaintext))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// Get issues an authenticated GET against path (e.g. "/api2/json/nodes"),
+// serving cached, possibly-stale-while-revalidating results when a shared
+// Cache was configured.
+func (c *Client) Get(path string) ([]byte, error) {
+	fetch := func() ([]byte, error) {
+		req, err := c.newRequest(http.MethodGet, path)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.http.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode >= 400 {
+			return nil, fmt.Errorf("pve: %s %s: %s", http.MethodGet, path, resp.Status)
+		}
+		return body, nil
+	}
+
+	if c.cache == nil {
+		return fetch()
+	}
+	return c.cache.Get(c.addr+path, c.tokenHash, "", fetch)
+}
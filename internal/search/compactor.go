@@ -0,0 +1,74 @@
+package search
+
+import (
+	"context"
+	"time"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/rs/zerolog/log"
+)
+
+const compactBatchSize = 500
+
+// Compactor periodically deletes documents older than Retention from an
+// Index so the on-disk size stays bounded on long-running deployments.
+type Compactor struct {
+	index     *Index
+	retention time.Duration
+	interval  time.Duration
+}
+
+// NewCompactor builds a Compactor that runs every interval, pruning
+// documents whose timestamp is older than retention.
+func NewCompactor(index *Index, retention, interval time.Duration) *Compactor {
+	return &Compactor{index: index, retention: retention, interval: interval}
+}
+
+// Run blocks, pruning on a ticker until ctx is cancelled.
+func (c *Compactor) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if n, err := c.prune(); err != nil {
+				log.Warn().Err(err).Msg("event index compaction failed")
+			} else if n > 0 {
+				log.Info().Int("pruned", n).Msg("event index compacted")
+			}
+		}
+	}
+}
+
+// prune removes every document older than the retention window and
+// returns how many were deleted.
+func (c *Compactor) prune() (int, error) {
+	cutoff := time.Now().Add(-c.retention)
+	total := 0
+
+	for {
+		q := bleve.NewDateRangeQuery(time.Time{}, cutoff)
+		q.SetField("ts")
+		req := bleve.NewSearchRequestOptions(q, compactBatchSize, 0, false)
+
+		res, err := c.index.bleve.Search(req)
+		if err != nil {
+			return total, err
+		}
+		if len(res.Hits) == 0 {
+			return total, nil
+		}
+
+		batch := c.index.bleve.NewBatch()
+		for _, hit := range res.Hits {
+			batch.Delete(hit.ID)
+		}
+		if err := c.index.bleve.Batch(batch); err != nil {
+			return total, err
+		}
+		total += len(res.Hits)
+	}
+}
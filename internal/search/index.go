@@ -0,0 +1,165 @@
+// Package search indexes alert/event history in an embedded bleve index so
+// the UI can run free-text queries over Pulse's historical state
+// transitions without standing up an external search service.
+package search
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/search/query"
+
+	"github.com/rcourtman/pulse-go-rewrite/internal/models"
+)
+
+const indexDirName = "events.bleve"
+
+// indexedAlert is the document shape stored in bleve. It mirrors
+// models.Alert but keeps the timestamp as a dedicated indexed field.
+type indexedAlert struct {
+	Timestamp time.Time `json:"ts"`
+	Node      string    `json:"node"`
+	Guest     string    `json:"guest"`
+	Severity  string    `json:"severity"`
+	Kind      string    `json:"kind"`
+	Message   string    `json:"message"`
+}
+
+// Index wraps a bleve index on disk for alert/event history.
+type Index struct {
+	bleve bleve.Index
+	dir   string
+}
+
+// Open creates or opens the event index under dataDir.
+func Open(dataDir string) (*Index, error) {
+	path := filepath.Join(dataDir, indexDirName)
+
+	idx, err := bleve.Open(path)
+	if err == nil {
+		return &Index{bleve: idx, dir: path}, nil
+	}
+	if err != bleve.ErrorIndexPathDoesNotExist {
+		return nil, fmt.Errorf("open event index: %w", err)
+	}
+
+	if err := os.MkdirAll(dataDir, 0o755); err != nil {
+		return nil, fmt.Errorf("create data dir: %w", err)
+	}
+
+	mapping := bleve.NewIndexMapping()
+	idx, err = bleve.New(path, mapping)
+	if err != nil {
+		return nil, fmt.Errorf("create event index: %w", err)
+	}
+	return &Index{bleve: idx, dir: path}, nil
+}
+
+// Close releases the underlying index files.
+func (i *Index) Close() error {
+	return i.bleve.Close()
+}
+
+// IndexAlert adds a single alert transition to the index. The document ID
+// is the alert's own ID so repeated indexing of the same alert updates it.
+func (i *Index) IndexAlert(a models.Alert) error {
+	doc := indexedAlert{
+		Timestamp: a.Timestamp,
+		Node:      a.Node,
+		Guest:     a.Guest,
+		Severity:  string(a.Severity),
+		Kind:      string(a.Kind),
+		Message:   a.Message,
+	}
+	return i.bleve.Index(a.ID, doc)
+}
+
+// Hit is a single search result, with a highlighted snippet of the message
+// field when bleve was able to produce one.
+type Hit struct {
+	ID        string    `json:"id"`
+	Score     float64   `json:"score"`
+	Timestamp time.Time `json:"timestamp"`
+	Node      string    `json:"node"`
+	Guest     string    `json:"guest,omitempty"`
+	Severity  string    `json:"severity"`
+	Kind      string    `json:"kind"`
+	Message   string    `json:"message"`
+	Highlight []string  `json:"highlight,omitempty"`
+}
+
+// Results is a page of search hits along with the total match count.
+type Results struct {
+	Total uint64 `json:"total"`
+	Hits  []Hit  `json:"hits"`
+}
+
+// SearchParams bounds a query with pagination and an optional time range.
+type SearchParams struct {
+	QueryString string
+	From, To    time.Time
+	Offset      int
+	Limit       int
+}
+
+// Search runs q against the index using bleve's query-string syntax (e.g.
+// `severity:critical +node:pve01 message:backup`) and returns a page of
+// results with highlighted snippets.
+func (i *Index) Search(p SearchParams) (*Results, error) {
+	qs := query.NewQueryStringQuery(p.QueryString)
+
+	var q query.Query = qs
+	if !p.From.IsZero() || !p.To.IsZero() {
+		from, to := p.From, p.To
+		dateQuery := bleve.NewDateRangeQuery(from, to)
+		dateQuery.SetField("ts")
+		q = bleve.NewConjunctionQuery(qs, dateQuery)
+	}
+
+	req := bleve.NewSearchRequestOptions(q, p.Limit, p.Offset, false)
+	req.Fields = []string{"ts", "node", "guest", "severity", "kind", "message"}
+	req.Highlight = bleve.NewHighlight()
+
+	res, err := i.bleve.Search(req)
+	if err != nil {
+		return nil, fmt.Errorf("search: %w", err)
+	}
+
+	out := &Results{Total: res.Total}
+	for _, hit := range res.Hits {
+		h := Hit{ID: hit.ID, Score: hit.Score}
+		if ts, ok := hit.Fields["ts"].(string); ok {
+			if t, err := time.Parse(time.RFC3339, ts); err == nil {
+				h.Timestamp = t
+			}
+		}
+		if v, ok := hit.Fields["node"].(string); ok {
+			h.Node = v
+		}
+		if v, ok := hit.Fields["guest"].(string); ok {
+			h.Guest = v
+		}
+		if v, ok := hit.Fields["severity"].(string); ok {
+			h.Severity = v
+		}
+		if v, ok := hit.Fields["kind"].(string); ok {
+			h.Kind = v
+		}
+		if v, ok := hit.Fields["message"].(string); ok {
+			h.Message = v
+		}
+		if frags, ok := hit.Fragments["message"]; ok {
+			h.Highlight = frags
+		}
+		out.Hits = append(out.Hits, h)
+	}
+	return out, nil
+}
+
+// DocCount returns the number of documents currently in the index.
+func (i *Index) DocCount() (uint64, error) {
+	return i.bleve.DocCount()
+}
@@ -0,0 +1,102 @@
+// Package secret protects sensitive values — Proxmox API tokens, PBS
+// credentials — from appearing in heap snapshots, core dumps, or swap by
+// keeping them in a memguard.LockedBuffer instead of a plain Go string.
+package secret
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/awnumar/memguard"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNotMarshalable is returned by Secret.MarshalJSON: secrets must never
+// be serialized into HTTP responses, WebSocket frames, or logs.
+var ErrNotMarshalable = errors.New("secret: refusing to marshal a secret value")
+
+// Secret holds a sensitive string inside a locked, non-swappable buffer.
+// The zero value is an empty secret.
+type Secret struct {
+	buf *memguard.LockedBuffer
+}
+
+// New copies plaintext into a locked buffer and wipes the input slice.
+// Callers should not reuse plaintext after calling New.
+func New(plaintext []byte) Secret {
+	if len(plaintext) == 0 {
+		return Secret{}
+	}
+	return Secret{buf: memguard.NewBufferFromBytes(plaintext)}
+}
+
+// FromString is a convenience wrapper around New for values loaded from
+// YAML or environment variables, which arrive as strings.
+func FromString(plaintext string) Secret {
+	return New([]byte(plaintext))
+}
+
+// Open decrypts the secret for the duration of fn and passes it the raw
+// bytes. The bytes are only valid inside fn; copying them out defeats the
+// point of this package.
+func (s Secret) Open(fn func(plaintext []byte) error) error {
+	if s.buf == nil {
+		return fn(nil)
+	}
+	return fn(s.buf.Bytes())
+}
+
+// IsEmpty reports whether the secret holds no value.
+func (s Secret) IsEmpty() bool {
+	return s.buf == nil || s.buf.Size() == 0
+}
+
+// String never reveals the secret value; it exists so Secret satisfies
+// fmt.Stringer and prints safely in logs.
+func (s Secret) String() string {
+	return "[REDACTED]"
+}
+
+// MarshalJSON always fails: secrets must never be encoded into JSON sent
+// over HTTP or WebSocket, or written to disk.
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return nil, ErrNotMarshalable
+}
+
+// UnmarshalJSON accepts a plain JSON string and stores it as a Secret, so
+// config loaders can decode tagged fields directly from YAML-as-JSON or
+// environment overlays without a separate conversion step.
+func (s *Secret) UnmarshalJSON(data []byte) error {
+	var plaintext string
+	if err := json.Unmarshal(data, &plaintext); err != nil {
+		return err
+	}
+	*s = FromString(plaintext)
+	return nil
+}
+
+// UnmarshalYAML lets Secret fields be populated directly from a YAML
+// document, matching how the rest of config.Config is parsed.
+func (s *Secret) UnmarshalYAML(value *yaml.Node) error {
+	var plaintext string
+	if err := value.Decode(&plaintext); err != nil {
+		return err
+	}
+	*s = FromString(plaintext)
+	return nil
+}
+
+// Purge destroys every LockedBuffer memguard currently tracks. Call this
+// once, on shutdown.
+//
+// There is deliberately no CatchInterrupt/CatchSignal wrapper here:
+// memguard's signal handler always calls os.Exit(1) once it runs,
+// regardless of what the handler itself does, which would race the
+// graceful signal.NotifyContext-based shutdown and win almost every
+// time — skipping Store/WebSocket/cluster cleanup and turning a plain
+// Ctrl-C into a non-zero exit. Callers should instead let their normal
+// shutdown path (ctx cancellation on SIGINT/SIGTERM) reach a deferred
+// call to Purge.
+func Purge() {
+	memguard.Purge()
+}
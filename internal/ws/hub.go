@@ -0,0 +1,76 @@
+// Package ws implements the WebSocket broadcast hub that streams collector
+// and system events (alerts, config reloads, search stats) out to connected
+// UI clients.
+package ws
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog/log"
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// Event is a single message broadcast to every connected client.
+type Event struct {
+	Type string `json:"type"`
+	Data any    `json:"data"`
+}
+
+// Hub tracks connected WebSocket clients and fans out events to all of them.
+type Hub struct {
+	mu      sync.Mutex
+	clients map[*websocket.Conn]struct{}
+}
+
+// NewHub creates an empty Hub.
+func NewHub() *Hub {
+	return &Hub{clients: make(map[*websocket.Conn]struct{})}
+}
+
+// ServeHTTP upgrades the request to a WebSocket connection and registers it
+// with the hub until the client disconnects.
+func (h *Hub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Warn().Err(err).Msg("websocket upgrade failed")
+		return
+	}
+
+	h.mu.Lock()
+	h.clients[conn] = struct{}{}
+	h.mu.Unlock()
+
+	defer func() {
+		h.mu.Lock()
+		delete(h.clients, conn)
+		h.mu.Unlock()
+		conn.Close()
+	}()
+
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+// Broadcast sends an event to every currently connected client, dropping it
+// for any client whose write fails (it will be reaped on its next read).
+func (h *Hub) Broadcast(eventType string, data any) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	event := Event{Type: eventType, Data: data}
+	for conn := range h.clients {
+		if err := conn.WriteJSON(event); err != nil {
+			log.Debug().Err(err).Msg("websocket broadcast failed, dropping client")
+		}
+	}
+}